@@ -0,0 +1,19 @@
+// Package headerloader is an alias for github.com/combo23/http-template
+// under the name some callers historically imported it by. All types and
+// functions are thin forwarders over the http_template package, which holds
+// the actual implementation; see its doc comments for behavior details.
+package headerloader
+
+import (
+	http_template "github.com/combo23/http-template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseHeaderTemplate forwards to http_template.ParseHeaderTemplate.
+func ParseHeaderTemplate(
+	templateStr string,
+	templateData interface{},
+) (http.Header, error) {
+	return http_template.ParseHeaderTemplate(templateStr, templateData)
+}
@@ -0,0 +1,20 @@
+package headerloader
+
+import (
+	"io/fs"
+
+	http_template "github.com/combo23/http-template"
+)
+
+// HeaderTemplate is an alias for http_template.HeaderTemplate.
+type HeaderTemplate = http_template.HeaderTemplate
+
+// ParseHeaderTemplateFiles forwards to http_template.ParseHeaderTemplateFiles.
+func ParseHeaderTemplateFiles(paths ...string) (*HeaderTemplate, error) {
+	return http_template.ParseHeaderTemplateFiles(paths...)
+}
+
+// ParseHeaderTemplateFS forwards to http_template.ParseHeaderTemplateFS.
+func ParseHeaderTemplateFS(fsys fs.FS, glob string) (*HeaderTemplate, error) {
+	return http_template.ParseHeaderTemplateFS(fsys, glob)
+}
@@ -0,0 +1,25 @@
+package headerloader
+
+import (
+	http_template "github.com/combo23/http-template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseOptions is an alias for http_template.ParseOptions.
+type ParseOptions = http_template.ParseOptions
+
+// DefaultParseOptions forwards to http_template.DefaultParseOptions.
+func DefaultParseOptions() ParseOptions {
+	return http_template.DefaultParseOptions()
+}
+
+// ParseHeaderTemplateWithOptions forwards to
+// http_template.ParseHeaderTemplateWithOptions.
+func ParseHeaderTemplateWithOptions(
+	templateStr string,
+	templateData interface{},
+	opts ParseOptions,
+) (http.Header, error) {
+	return http_template.ParseHeaderTemplateWithOptions(templateStr, templateData, opts)
+}
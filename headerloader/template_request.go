@@ -0,0 +1,15 @@
+package headerloader
+
+import (
+	http_template "github.com/combo23/http-template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseRequestTemplate forwards to http_template.ParseRequestTemplate.
+func ParseRequestTemplate(
+	templateStr string,
+	templateData interface{},
+) (*http.Request, error) {
+	return http_template.ParseRequestTemplate(templateStr, templateData)
+}
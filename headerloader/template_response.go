@@ -0,0 +1,20 @@
+package headerloader
+
+import (
+	http_template "github.com/combo23/http-template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseResponseTemplate forwards to http_template.ParseResponseTemplate.
+func ParseResponseTemplate(
+	templateStr string,
+	templateData interface{},
+) (*http.Response, error) {
+	return http_template.ParseResponseTemplate(templateStr, templateData)
+}
+
+// DumpHeader forwards to http_template.DumpHeader.
+func DumpHeader(h http.Header) string {
+	return http_template.DumpHeader(h)
+}
@@ -0,0 +1,143 @@
+package http_template
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultUserAgents backs the "randomUA" template func with a small pool of
+// realistic desktop and mobile browser User-Agent strings.
+var defaultUserAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+}
+
+// defaultTemplateFuncs returns the FuncMap registered by default on every
+// template parsed by ParseHeaderTemplateWithOptions. It supplies helpers
+// commonly needed when templating realistic browser headers, so callers
+// don't need to pre-process their data before calling the parser.
+func defaultTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"randomUA": func() string {
+			return defaultUserAgents[mathrand.Intn(len(defaultUserAgents))]
+		},
+		"uuid": newUUIDv4,
+		"now": func(layout string) string {
+			return time.Now().Format(layout)
+		},
+		"base64": func(s string) string {
+			return base64.StdEncoding.EncodeToString([]byte(s))
+		},
+		"hex": func(s string) string {
+			return hex.EncodeToString([]byte(s))
+		},
+		"randInt": func(a, b int) (int, error) {
+			if a > b {
+				return 0, fmt.Errorf("randInt: min %d is greater than max %d", a, b)
+			}
+			return a + mathrand.Intn(b-a+1), nil
+		},
+		"pickWeighted":  pickWeighted,
+		"joinCookies":   joinCookies,
+		"secChUaFromUA": secChUaFromUA,
+		"sha256": func(s string) string {
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:])
+		},
+	}
+}
+
+// mergeTemplateFuncs overlays extra on top of defaultTemplateFuncs, letting
+// callers override or add project-specific template helpers.
+func mergeTemplateFuncs(extra template.FuncMap) template.FuncMap {
+	funcs := defaultTemplateFuncs()
+	for name, fn := range extra {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// newUUIDv4 returns a random RFC 4122 version 4 UUID string.
+func newUUIDv4() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// pickWeighted picks a random key from weights, where each key's chance of
+// being chosen is proportional to its weight. Non-positive weights are
+// ignored. Returns "" if no key has a positive weight.
+func pickWeighted(weights map[string]int) string {
+	total := 0
+	keys := make([]string, 0, len(weights))
+	for k, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		keys = append(keys, k)
+		total += w
+	}
+	if total == 0 {
+		return ""
+	}
+	sort.Strings(keys) // deterministic key order before the random draw
+
+	r := mathrand.Intn(total)
+	for _, k := range keys {
+		r -= weights[k]
+		if r < 0 {
+			return k
+		}
+	}
+	return keys[len(keys)-1]
+}
+
+// joinCookies renders a map of cookie name/value pairs as a single
+// "name=value; name2=value2" Cookie header value, with names sorted for a
+// deterministic rendering.
+func joinCookies(cookies map[string]string) string {
+	names := make([]string, 0, len(cookies))
+	for name := range cookies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, cookies[name]))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// secChUaFromUA derives a best-effort Sec-CH-UA header value from a
+// Chrome/Chromium User-Agent string. Non-Chromium user agents yield "".
+func secChUaFromUA(ua string) string {
+	idx := strings.Index(ua, "Chrome/")
+	if idx == -1 {
+		return ""
+	}
+	rest := ua[idx+len("Chrome/"):]
+	end := strings.IndexAny(rest, ". ")
+	if end == -1 {
+		end = len(rest)
+	}
+	major := rest[:end]
+	return fmt.Sprintf(
+		`"Chromium";v="%s", "Google Chrome";v="%s", "Not-A.Brand";v="99"`,
+		major, major,
+	)
+}
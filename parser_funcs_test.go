@@ -0,0 +1,49 @@
+package http_template
+
+import "testing"
+
+func TestRandInt_ErrorsOnInvertedRange(t *testing.T) {
+	funcs := defaultTemplateFuncs()
+	randInt := funcs["randInt"].(func(int, int) (int, error))
+
+	n, err := randInt(1, 5)
+	if err != nil {
+		t.Fatalf("randInt(1, 5) returned error: %v", err)
+	}
+	if n < 1 || n > 5 {
+		t.Errorf("randInt(1, 5) = %d, want value in [1, 5]", n)
+	}
+
+	if _, err := randInt(5, 1); err == nil {
+		t.Error("randInt(5, 1) should error when min > max, not panic")
+	}
+}
+
+func TestPickWeighted(t *testing.T) {
+	if got := pickWeighted(map[string]int{"a": 1}); got != "a" {
+		t.Errorf("pickWeighted single positive weight = %q, want %q", got, "a")
+	}
+	if got := pickWeighted(map[string]int{"a": 0, "b": -1}); got != "" {
+		t.Errorf("pickWeighted all non-positive weights = %q, want \"\"", got)
+	}
+}
+
+func TestJoinCookies(t *testing.T) {
+	got := joinCookies(map[string]string{"b": "2", "a": "1"})
+	want := "a=1; b=2"
+	if got != want {
+		t.Errorf("joinCookies = %q, want %q", got, want)
+	}
+}
+
+func TestSecChUaFromUA(t *testing.T) {
+	ua := "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+	got := secChUaFromUA(ua)
+	if got == "" {
+		t.Fatal("secChUaFromUA returned empty string for a Chrome UA")
+	}
+
+	if got := secChUaFromUA("Mozilla/5.0 (compatible; Firefox)"); got != "" {
+		t.Errorf("secChUaFromUA for a non-Chromium UA = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,86 @@
+package http_template
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// HeaderTemplate is a compiled, reusable header template. Unlike
+// ParseHeaderTemplate, which parses the template string on every call, a
+// HeaderTemplate parses its source once and can be Execute'd repeatedly,
+// which matters for high-QPS callers such as scrapers.
+type HeaderTemplate struct {
+	tmpl *template.Template
+	opts ParseOptions
+}
+
+// ParseHeaderTemplateFiles loads and parses the named template files into a
+// reusable *HeaderTemplate. The first file's base name becomes the template
+// invoked by Execute; the rest are made available for {{template "name" .}}
+// inclusion (e.g. a library of reusable header fragments such as
+// "chrome_desktop.tmpl" or "auth_bearer.tmpl"), following the same
+// conventions as text/template.ParseFiles.
+func ParseHeaderTemplateFiles(paths ...string) (*HeaderTemplate, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no template files provided")
+	}
+
+	tmpl, err := template.New(filepath.Base(paths[0])).
+		Funcs(mergeTemplateFuncs(nil)).
+		ParseFiles(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template files: %w", err)
+	}
+
+	return &HeaderTemplate{tmpl: tmpl, opts: DefaultParseOptions()}, nil
+}
+
+// ParseHeaderTemplateFS loads and parses the template files matched by glob
+// out of fsys into a reusable *HeaderTemplate, following the same
+// conventions as text/template.ParseFS. This lets callers embed a library of
+// header fragments with go:embed and compose them per request via
+// {{template "partial" .}} inclusion.
+func ParseHeaderTemplateFS(fsys fs.FS, glob string) (*HeaderTemplate, error) {
+	matches, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob template filesystem: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no template files matched glob %q", glob)
+	}
+
+	// Name the root template after the first match's base name, exactly as
+	// ParseHeaderTemplateFiles does, so Execute invokes a template that
+	// actually has a parsed body instead of an empty nameless root.
+	tmpl, err := template.New(filepath.Base(matches[0])).
+		Funcs(mergeTemplateFuncs(nil)).
+		ParseFS(fsys, glob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template filesystem: %w", err)
+	}
+
+	return &HeaderTemplate{tmpl: tmpl, opts: DefaultParseOptions()}, nil
+}
+
+// WithOptions returns a copy of ht that parses its rendered output using
+// opts instead of DefaultParseOptions.
+func (ht *HeaderTemplate) WithOptions(opts ParseOptions) *HeaderTemplate {
+	return &HeaderTemplate{tmpl: ht.tmpl, opts: opts}
+}
+
+// Execute renders the compiled template with data and parses the result
+// into an http.Header, exactly as ParseHeaderTemplateWithOptions would, but
+// without re-parsing the template source on every call.
+func (ht *HeaderTemplate) Execute(data interface{}) (http.Header, error) {
+	var buf strings.Builder
+	if err := ht.tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return parseProcessedHeaders(buf.String(), ht.opts)
+}
@@ -0,0 +1,87 @@
+package http_template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseHeaderTemplateFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chrome.tmpl")
+	if err := os.WriteFile(path, []byte("GET / HTTP/2\nAccept: text/html\n"), 0o644); err != nil {
+		t.Fatalf("failed to write template file: %v", err)
+	}
+
+	ht, err := ParseHeaderTemplateFiles(path)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplateFiles returned error: %v", err)
+	}
+
+	h, err := ht.Execute(nil)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := h.Get("Accept"), "text/html"; got != want {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestParseHeaderTemplateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"chrome.tmpl": &fstest.MapFile{
+			Data: []byte("GET / HTTP/2\nAccept: {{template \"partial\" .}}\n"),
+		},
+		"partial.tmpl": &fstest.MapFile{
+			Data: []byte(`{{define "partial"}}text/html{{end}}`),
+		},
+	}
+
+	ht, err := ParseHeaderTemplateFS(fsys, "*.tmpl")
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplateFS returned error: %v", err)
+	}
+
+	h, err := ht.Execute(nil)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := h.Get("Accept"), "text/html"; got != want {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestParseHeaderTemplateFS_NoMatches(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	if _, err := ParseHeaderTemplateFS(fsys, "*.tmpl"); err == nil {
+		t.Error("ParseHeaderTemplateFS should error when the glob matches no files")
+	}
+}
+
+func TestHeaderTemplate_WithOptions(t *testing.T) {
+	ht, err := ParseHeaderTemplateFiles(writeTempTemplate(t, "GET / HTTP/2\ncookie: a=1\n"))
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplateFiles returned error: %v", err)
+	}
+
+	h, err := ht.WithOptions(ParseOptions{}).Execute(nil)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	// The header keeps its original lowercase casing, so look it up
+	// case-insensitively rather than via Get (which canonicalizes the key).
+	if got, want := headerGetCI(h, "cookie"), "a=1"; got != want {
+		t.Errorf("cookie = %q, want %q (not order-only with empty ParseOptions)", got, want)
+	}
+}
+
+func writeTempTemplate(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tmpl.tmpl")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write template file: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,277 @@
+package http_template
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseOptions configures how ParseHeaderTemplateWithOptions treats
+// individual headers encountered while parsing a rendered template.
+type ParseOptions struct {
+	// OrderOnlyHeaders lists header keys (lowercased) that should be
+	// recorded in the http.HeaderOrderKey order list but excluded from the
+	// main header value map, mirroring the historic "cookie" /
+	// "content-length" special cases.
+	OrderOnlyHeaders []string
+
+	// DedupeOrderOnlyHeaders lists header keys (lowercased) from
+	// OrderOnlyHeaders that should be added to the order list only once, no
+	// matter how many times they appear in the template.
+	DedupeOrderOnlyHeaders []string
+
+	// KeyNormalizer, if set, is applied to each regular header key before it
+	// is stored in the header map and order list. It defaults to nil, which
+	// preserves the template's original casing. Pass
+	// textproto.CanonicalMIMEHeaderKey to canonicalize casing instead.
+	KeyNormalizer func(string) string
+
+	// ExtraFuncs are registered alongside the built-in template FuncMap
+	// (randomUA, uuid, now, base64, hex, randInt, pickWeighted, joinCookies,
+	// secChUaFromUA, sha256), overriding a built-in of the same name if
+	// present. Use it to expose project-specific template helpers.
+	ExtraFuncs template.FuncMap
+}
+
+// DefaultParseOptions returns the ParseOptions used by ParseHeaderTemplate:
+// "cookie" and "content-length" are order-only, "cookie" is deduped, and
+// keys keep their original casing.
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{
+		OrderOnlyHeaders:       []string{"cookie", "content-length"},
+		DedupeOrderOnlyHeaders: []string{"cookie"},
+	}
+}
+
+// ParseHeaderTemplateWithOptions behaves like ParseHeaderTemplate but lets
+// the caller configure which headers are order-only (recorded in the order
+// list but excluded from the header map), which of those are deduped, and
+// how regular header keys are normalized before storage. See ParseOptions.
+func ParseHeaderTemplateWithOptions(
+	templateStr string,
+	templateData interface{},
+	opts ParseOptions,
+) (http.Header, error) {
+	var processedStringBuffer bytes.Buffer
+	tmpl, err := template.New("httpHeaderTemplate").
+		Funcs(mergeTemplateFuncs(opts.ExtraFuncs)).
+		Parse(templateStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template string: %w", err)
+	}
+
+	err = tmpl.Execute(&processedStringBuffer, templateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return parseProcessedHeaders(processedStringBuffer.String(), opts)
+}
+
+// parseProcessedHeaders scans the already-rendered output of a header
+// template (first line is the ignored request line) and builds the
+// resulting http.Header, applying opts. It is shared by
+// ParseHeaderTemplateWithOptions and HeaderTemplate.Execute.
+func parseProcessedHeaders(processedStr string, opts ParseOptions) (http.Header, error) {
+	lines := splitProcessedLines(processedStr)
+	if len(lines) == 0 {
+		return make(http.Header), nil // Empty template
+	}
+
+	headers, _, err := parseHeaderLines(lines[1:], opts)
+	return headers, err
+}
+
+// splitProcessedLines splits the rendered template output into lines,
+// accepting both "\n" and "\r\n" endings.
+func splitProcessedLines(processedStr string) []string {
+	trimmed := strings.TrimRight(processedStr, "\n")
+	if trimmed == "" {
+		return nil
+	}
+	lines := strings.Split(trimmed, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+	}
+	return lines
+}
+
+// parseHeaderLines parses a block of header lines (pseudo-header and
+// regular "key: value" lines, with RFC 5322 folding and ParseOptions'
+// order-only/key-normalization policy applied) until the first blank line.
+// It returns the parsed headers plus, if a blank line was found, every line
+// after it verbatim as the message body; shared by ParseHeaderTemplateWithOptions,
+// ParseRequestTemplate and ParseResponseTemplate so the three entry points
+// can't drift from one another.
+func parseHeaderLines(lines []string, opts ParseOptions) (http.Header, []string, error) {
+	outputHeaders := make(http.Header)
+	var pseudoHeaderOrder []string
+	var regularHeaderOrder []string
+	orderOnlyAddedToOrder := make(map[string]bool)
+
+	var lastHeaderKey string
+	lastHeaderFoldable := false
+
+	for i, line := range lines {
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			// RFC 5322 continuation line: fold into the previous header's
+			// value with a single space separator.
+			foldedValue := strings.TrimSpace(line)
+			if foldedValue != "" && lastHeaderFoldable {
+				if existingValues, ok := outputHeaders[lastHeaderKey]; ok &&
+					len(existingValues) > 0 {
+					lastIdx := len(existingValues) - 1
+					existingValues[lastIdx] = strings.TrimSpace(
+						existingValues[lastIdx] + " " + foldedValue,
+					)
+				}
+			}
+			continue
+		}
+
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" {
+			// Blank line: headers are done, everything after is body.
+			finalizeHeaderOrder(outputHeaders, pseudoHeaderOrder, regularHeaderOrder)
+			return outputHeaders, lines[i+1:], nil
+		}
+
+		var key, value string
+
+		firstColonIdx := strings.IndexByte(trimmedLine, ':')
+
+		if firstColonIdx == -1 {
+			// No colon in the line, not a valid "key: value" format
+			continue
+		}
+
+		potentialKeyPart := trimmedLine[:firstColonIdx]
+		potentialValuePart := ""
+		if firstColonIdx < len(trimmedLine)-1 {
+			potentialValuePart = trimmedLine[firstColonIdx+1:]
+		}
+
+		if strings.TrimSpace(potentialKeyPart) == "" &&
+			strings.HasPrefix(trimmedLine, ":") {
+			// Pseudo-header pattern like ":method: GET"
+			secondColonIdxInPVP := strings.IndexByte(potentialValuePart, ':')
+			actualPseudoKeyNamePart := ""
+
+			if secondColonIdxInPVP == -1 {
+				actualPseudoKeyNamePart = strings.TrimSpace(potentialValuePart)
+				value = ""
+			} else {
+				actualPseudoKeyNamePart = strings.TrimSpace(
+					potentialValuePart[:secondColonIdxInPVP],
+				)
+				if secondColonIdxInPVP < len(potentialValuePart)-1 {
+					value = strings.TrimSpace(
+						potentialValuePart[secondColonIdxInPVP+1:],
+					)
+				} else {
+					value = ""
+				}
+			}
+			if actualPseudoKeyNamePart == "" {
+				key = ":"
+			} else {
+				key = ":" + actualPseudoKeyNamePart
+			}
+		} else {
+			// Regular header or other format
+			key = strings.TrimSpace(potentialKeyPart)
+			value = strings.TrimSpace(potentialValuePart)
+		}
+
+		if key == "" {
+			continue
+		}
+
+		if strings.HasPrefix(key, ":") {
+			// This is a pseudo-header. Its value is deliberately NOT added
+			// to outputHeaders: fhttp's http2 transport validates every key
+			// in req.Header with httpguts.ValidHeaderFieldName, exempting
+			// only HeaderOrderKey/PHeaderOrderKey/":protocol" — a literal
+			// ":method"/":path"/etc. key in the map makes RoundTrip fail
+			// (or, over HTTP/1.1, gets written onto the wire as a bogus
+			// header line). DumpHeader renders pseudo-headers value-less.
+			pseudoHeaderOrder = append(pseudoHeaderOrder, key)
+			// Pseudo-headers are not subject to RFC 5322 folding.
+			lastHeaderKey = ""
+			lastHeaderFoldable = false
+			continue
+		}
+
+		// This is a regular HTTP header.
+		if opts.KeyNormalizer != nil {
+			key = opts.KeyNormalizer(key)
+		}
+		lowerKey := strings.ToLower(key)
+
+		if orderOnlyIndex(opts.OrderOnlyHeaders, lowerKey) {
+			if orderOnlyIndex(opts.DedupeOrderOnlyHeaders, lowerKey) {
+				if !orderOnlyAddedToOrder[lowerKey] {
+					regularHeaderOrder = append(regularHeaderOrder, key)
+					orderOnlyAddedToOrder[lowerKey] = true
+				}
+			} else {
+				regularHeaderOrder = append(regularHeaderOrder, key)
+			}
+			// Order-only headers are NOT added to the outputHeaders map.
+			lastHeaderKey = ""
+			lastHeaderFoldable = false
+		} else {
+			regularHeaderOrder = append(regularHeaderOrder, key)
+			if existingValues, ok := outputHeaders[key]; ok {
+				outputHeaders[key] = append(existingValues, value)
+			} else {
+				outputHeaders[key] = []string{value}
+			}
+			lastHeaderKey = key
+			lastHeaderFoldable = true
+		}
+	}
+
+	finalizeHeaderOrder(outputHeaders, pseudoHeaderOrder, regularHeaderOrder)
+	return outputHeaders, nil, nil
+}
+
+// finalizeHeaderOrder attaches the recorded pseudo/regular header order
+// lists to h under their respective fhttp order keys, if non-empty.
+func finalizeHeaderOrder(h http.Header, pseudoHeaderOrder, regularHeaderOrder []string) {
+	if len(pseudoHeaderOrder) > 0 {
+		h[http.PHeaderOrderKey] = pseudoHeaderOrder
+	}
+	if len(regularHeaderOrder) > 0 {
+		h[http.HeaderOrderKey] = regularHeaderOrder
+	}
+}
+
+// orderOnlyIndex reports whether lowerKey (already lowercased) appears in
+// headers (expected to already be lowercased, per ParseOptions' doc).
+func orderOnlyIndex(headers []string, lowerKey string) bool {
+	for _, h := range headers {
+		if strings.ToLower(h) == lowerKey {
+			return true
+		}
+	}
+	return false
+}
+
+// headerGetCI looks up key in h case-insensitively, scanning the stored
+// keys directly rather than going through http.Header.Get's canonicalizing
+// lookup. This is needed because the parser deliberately preserves a
+// header's original casing (e.g. the lowercase "host:" seen in HTTP/2
+// devtools captures), which http.Header.Get's canonical-key-only lookup
+// would otherwise miss. It returns the first value found, or "".
+func headerGetCI(h http.Header, key string) string {
+	for k, values := range h {
+		if len(values) > 0 && strings.EqualFold(k, key) {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package http_template
+
+import (
+	"net/textproto"
+	"testing"
+	"text/template"
+)
+
+func TestParseHeaderTemplateWithOptions_CustomOrderOnly(t *testing.T) {
+	tmpl := "GET / HTTP/2\n" +
+		"X-Trace-Id: ignored\n" +
+		"Accept: text/html\n"
+
+	opts := ParseOptions{
+		OrderOnlyHeaders: []string{"x-trace-id"},
+	}
+
+	h, err := ParseHeaderTemplateWithOptions(tmpl, nil, opts)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplateWithOptions returned error: %v", err)
+	}
+
+	if _, ok := h["X-Trace-Id"]; ok {
+		t.Errorf("X-Trace-Id should be order-only, got value %v", h["X-Trace-Id"])
+	}
+	if got, want := h.Get("Accept"), "text/html"; got != want {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestParseHeaderTemplateWithOptions_KeyNormalizer(t *testing.T) {
+	tmpl := "GET / HTTP/2\naccept: text/html\n"
+
+	opts := DefaultParseOptions()
+	opts.KeyNormalizer = textproto.CanonicalMIMEHeaderKey
+
+	h, err := ParseHeaderTemplateWithOptions(tmpl, nil, opts)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplateWithOptions returned error: %v", err)
+	}
+
+	if got, want := h.Get("Accept"), "text/html"; got != want {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestParseHeaderTemplateWithOptions_ExtraFuncs(t *testing.T) {
+	tmpl := "GET / HTTP/2\nX-Custom: {{shout \"hi\"}}\n"
+
+	opts := DefaultParseOptions()
+	opts.ExtraFuncs = template.FuncMap{
+		"shout": func(s string) string { return s + "!" },
+	}
+
+	h, err := ParseHeaderTemplateWithOptions(tmpl, nil, opts)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplateWithOptions returned error: %v", err)
+	}
+
+	if got, want := h.Get("X-Custom"), "hi!"; got != want {
+		t.Errorf("X-Custom = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultParseOptions(t *testing.T) {
+	opts := DefaultParseOptions()
+
+	if !orderOnlyIndex(opts.OrderOnlyHeaders, "cookie") {
+		t.Error("cookie should be order-only by default")
+	}
+	if !orderOnlyIndex(opts.OrderOnlyHeaders, "content-length") {
+		t.Error("content-length should be order-only by default")
+	}
+	if !orderOnlyIndex(opts.DedupeOrderOnlyHeaders, "cookie") {
+		t.Error("cookie should be deduped by default")
+	}
+	if orderOnlyIndex(opts.DedupeOrderOnlyHeaders, "content-length") {
+		t.Error("content-length should not be deduped by default")
+	}
+}
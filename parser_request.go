@@ -0,0 +1,113 @@
+package http_template
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"text/template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseRequestTemplate processes a template string with given data and parses
+// the rendered output as a complete raw HTTP/1.x or HTTP/2 request message:
+// a request line (e.g., "GET /path HTTP/2"), headers, a blank line, and an
+// optional body.
+//
+// This lets callers author an entire captured request (e.g., copied from
+// browser devtools or `curl -v` output) as a single template, rather than
+// only the header block handled by ParseHeaderTemplate.
+//
+// The returned *http.Request has its Method, URL, Proto (and ProtoMajor /
+// ProtoMinor), Body and Header populated. Headers are parsed using the same
+// parseHeaderLines logic as ParseHeaderTemplate, with DefaultParseOptions:
+// pseudo-headers are recorded in http.PHeaderOrderKey, regular header order
+// is recorded in http.HeaderOrderKey, "cookie" and "content-length" header
+// values are excluded from the header map but kept in the order list, and
+// the template FuncMap (randomUA, uuid, etc.) is available.
+func ParseRequestTemplate(
+	templateStr string,
+	templateData interface{},
+) (*http.Request, error) {
+	opts := DefaultParseOptions()
+
+	var processedStringBuffer bytes.Buffer
+	tmpl, err := template.New("httpRequestTemplate").
+		Funcs(mergeTemplateFuncs(opts.ExtraFuncs)).
+		Parse(templateStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template string: %w", err)
+	}
+
+	if err := tmpl.Execute(&processedStringBuffer, templateData); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	lines := splitProcessedLines(processedStringBuffer.String())
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("template output is empty, expected a request line")
+	}
+
+	method, path, proto, err := parseRequestLine(lines[0])
+	if err != nil {
+		return nil, err
+	}
+
+	outputHeaders, bodyLines, err := parseHeaderLines(lines[1:], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request path %q: %w", path, err)
+	}
+
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok && proto == "HTTP/2" {
+		// "HTTP/2" carries no minor version, which ParseHTTPVersion rejects.
+		major, minor, ok = 2, 0, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid HTTP version %q", proto)
+	}
+
+	req := &http.Request{
+		Method:     method,
+		URL:        u,
+		Proto:      proto,
+		ProtoMajor: major,
+		ProtoMinor: minor,
+		Header:     outputHeaders,
+		Host:       u.Host,
+	}
+
+	if hostHeader := headerGetCI(outputHeaders, "Host"); hostHeader != "" {
+		req.Host = hostHeader
+	}
+
+	if len(bodyLines) > 0 {
+		bodyStr := strings.Join(bodyLines, "\n")
+		req.ContentLength = int64(len(bodyStr))
+		req.Body = io.NopCloser(strings.NewReader(bodyStr))
+	} else {
+		req.Body = http.NoBody
+	}
+
+	return req, nil
+}
+
+// parseRequestLine splits a raw HTTP request line (e.g., "GET /path HTTP/2")
+// into its method, path and protocol version components.
+func parseRequestLine(line string) (method, path, proto string, err error) {
+	fields := strings.Fields(strings.TrimSpace(line))
+	if len(fields) != 3 {
+		return "", "", "", fmt.Errorf(
+			"malformed request line %q: expected \"METHOD path HTTP/version\"",
+			line,
+		)
+	}
+	return fields[0], fields[1], fields[2], nil
+}
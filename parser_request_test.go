@@ -0,0 +1,61 @@
+package http_template
+
+import "testing"
+
+func TestParseRequestTemplate_Basic(t *testing.T) {
+	tmpl := "POST /login HTTP/2.0\n" +
+		":method: POST\n" +
+		"Accept: text/html,\n" +
+		" application/json\n" +
+		"host: example.com\n" +
+		"\n" +
+		"user=alice"
+
+	req, err := ParseRequestTemplate(tmpl, nil)
+	if err != nil {
+		t.Fatalf("ParseRequestTemplate returned error: %v", err)
+	}
+
+	if req.Method != "POST" {
+		t.Errorf("Method = %q, want %q", req.Method, "POST")
+	}
+	if req.URL.Path != "/login" {
+		t.Errorf("URL.Path = %q, want %q", req.URL.Path, "/login")
+	}
+	if got, want := req.Header.Get("Accept"), "text/html, application/json"; got != want {
+		t.Errorf("Accept = %q, want %q (folding not applied)", got, want)
+	}
+	if req.Host != "example.com" {
+		t.Errorf("Host = %q, want %q (lowercase host header should win case-insensitively)", req.Host, "example.com")
+	}
+
+	body := make([]byte, len("user=alice"))
+	if _, err := req.Body.Read(body); err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if string(body) != "user=alice" {
+		t.Errorf("body = %q, want %q", string(body), "user=alice")
+	}
+}
+
+func TestParseRequestTemplate_UsesFuncMap(t *testing.T) {
+	tmpl := "GET / HTTP/2.0\nUser-Agent: {{randomUA}}\n"
+
+	req, err := ParseRequestTemplate(tmpl, nil)
+	if err != nil {
+		t.Fatalf("ParseRequestTemplate returned error: %v", err)
+	}
+	if req.Header.Get("User-Agent") == "" {
+		t.Error("User-Agent should be populated by the randomUA template func")
+	}
+}
+
+func TestParseRequestTemplate_NoBody(t *testing.T) {
+	req, err := ParseRequestTemplate("GET / HTTP/2.0\nAccept: */*\n", nil)
+	if err != nil {
+		t.Fatalf("ParseRequestTemplate returned error: %v", err)
+	}
+	if req.Body == nil {
+		t.Fatal("Body should never be nil")
+	}
+}
@@ -0,0 +1,153 @@
+package http_template
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// ParseResponseTemplate processes a template string with given data and
+// parses the rendered output as an HTTP response message: a status line
+// (e.g., "HTTP/2 200 OK") followed by headers. It returns a populated
+// *http.Response, symmetric to ParseRequestTemplate.
+//
+// Headers are parsed using the same parseHeaderLines logic as
+// ParseHeaderTemplate, with DefaultParseOptions: pseudo-headers are
+// recorded in http.PHeaderOrderKey, regular header order is recorded in
+// http.HeaderOrderKey, "cookie" and "content-length" header values are
+// excluded from the header map but kept in the order list, and the
+// template FuncMap (randomUA, uuid, etc.) is available.
+func ParseResponseTemplate(
+	templateStr string,
+	templateData interface{},
+) (*http.Response, error) {
+	opts := DefaultParseOptions()
+
+	var processedStringBuffer bytes.Buffer
+	tmpl, err := template.New("httpResponseTemplate").
+		Funcs(mergeTemplateFuncs(opts.ExtraFuncs)).
+		Parse(templateStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template string: %w", err)
+	}
+
+	if err := tmpl.Execute(&processedStringBuffer, templateData); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	lines := splitProcessedLines(processedStringBuffer.String())
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("template output is empty, expected a status line")
+	}
+
+	proto, statusCode, status, err := parseStatusLine(lines[0])
+	if err != nil {
+		return nil, err
+	}
+
+	outputHeaders, _, err := parseHeaderLines(lines[1:], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok && proto == "HTTP/2" {
+		// "HTTP/2" carries no minor version, which ParseHTTPVersion rejects.
+		major, minor, ok = 2, 0, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid HTTP version %q", proto)
+	}
+
+	resp := &http.Response{
+		Status:     status,
+		StatusCode: statusCode,
+		Proto:      proto,
+		ProtoMajor: major,
+		ProtoMinor: minor,
+		Header:     outputHeaders,
+		Body:       http.NoBody,
+	}
+
+	return resp, nil
+}
+
+// parseStatusLine splits a raw HTTP status line (e.g., "HTTP/2 200 OK")
+// into its protocol version, numeric status code and status text.
+func parseStatusLine(line string) (proto string, statusCode int, status string, err error) {
+	fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+	if len(fields) < 2 {
+		return "", 0, "", fmt.Errorf(
+			"malformed status line %q: expected \"HTTP/version code [text]\"",
+			line,
+		)
+	}
+
+	code, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, "", fmt.Errorf("invalid status code in status line %q: %w", line, err)
+	}
+
+	statusText := ""
+	if len(fields) == 3 {
+		statusText = fields[2]
+	}
+
+	return fields[0], code, fmt.Sprintf("%d %s", code, statusText), nil
+}
+
+// DumpHeader renders an http.Header (as produced by ParseHeaderTemplate or
+// ParseRequestTemplate) back into a text block of "key: value" lines,
+// pseudo-headers first in their recorded order followed by regular headers
+// in their recorded order, with original key casing preserved.
+//
+// Regular headers round-trip their values faithfully through
+// ParseHeaderTemplate. Pseudo-headers (":method", etc.) and order-only
+// headers (e.g. "cookie", "content-length" — see ParseOptions) are
+// rendered with an empty value ("key: \n"): their values are deliberately
+// never added to the header map in the first place, since fhttp's
+// transports reject or mishandle literal pseudo-header keys in a real
+// http.Header, so there is nothing to dump but the key itself.
+func DumpHeader(h http.Header) string {
+	var sb strings.Builder
+
+	dumpOrdered := func(order []string) {
+		// A repeated header is listed once per occurrence in the order list:
+		// each occurrence writes its next value, and the last occurrence
+		// writes whatever values remain.
+		remaining := make(map[string]int)
+		for _, key := range order {
+			remaining[key]++
+		}
+		written := make(map[string]int)
+		for _, key := range order {
+			remaining[key]--
+			values, ok := h[key]
+			if !ok || len(values) == 0 {
+				sb.WriteString(key)
+				sb.WriteString(": \n")
+				continue
+			}
+			start, end := written[key], len(values)
+			if remaining[key] > 0 && start+1 < end {
+				end = start + 1
+			}
+			written[key] = end
+			for _, value := range values[start:end] {
+				sb.WriteString(key)
+				sb.WriteString(": ")
+				sb.WriteString(value)
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	dumpOrdered(h[http.PHeaderOrderKey])
+	dumpOrdered(h[http.HeaderOrderKey])
+
+	return sb.String()
+}
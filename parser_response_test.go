@@ -0,0 +1,86 @@
+package http_template
+
+import (
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+func TestParseResponseTemplate_Basic(t *testing.T) {
+	tmpl := "HTTP/2.0 200 OK\n" +
+		":status: 200\n" +
+		"Content-Type: application/json\n" +
+		"cookie: a=1\n" +
+		"cookie: b=2\n"
+
+	resp, err := ParseResponseTemplate(tmpl, nil)
+	if err != nil {
+		t.Fatalf("ParseResponseTemplate returned error: %v", err)
+	}
+
+	if resp.StatusCode != 200 {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if got, want := resp.Header.Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("Content-Type = %q, want %q", got, want)
+	}
+	if _, ok := resp.Header["cookie"]; ok {
+		t.Error("cookie should be order-only, not present in the header map")
+	}
+}
+
+func TestDumpHeader_RoundTripsRegularHeadersButNotOrderOnlyValues(t *testing.T) {
+	tmpl := "GET / HTTP/2.0\n" +
+		":method: GET\n" +
+		":path: /\n" +
+		"Accept: text/html\n" +
+		"cookie: sessionid=abc123\n"
+
+	h, err := ParseHeaderTemplate(tmpl, nil)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplate returned error: %v", err)
+	}
+
+	dumped := DumpHeader(h)
+
+	reparsed, err := ParseHeaderTemplate("GET / HTTP/2.0\n"+dumped, nil)
+	if err != nil {
+		t.Fatalf("re-parsing dumped header failed: %v", err)
+	}
+
+	// Regular headers round-trip their value faithfully.
+	if got, want := reparsed.Get("Accept"), "text/html"; got != want {
+		t.Errorf("Accept after round-trip = %q, want %q", got, want)
+	}
+
+	// Pseudo-headers and order-only headers (cookie, content-length) are
+	// never added to the header map in the first place — storing a
+	// pseudo-header's value there would make fhttp's http2 transport
+	// reject the request outright — so DumpHeader can only emit their key
+	// with an empty value, and that's what a round-trip parse sees back.
+	if _, ok := reparsed[":method"]; ok {
+		t.Errorf(":method should not be present in the reparsed header map, got %v", reparsed[":method"])
+	}
+	if _, ok := reparsed[":path"]; ok {
+		t.Errorf(":path should not be present in the reparsed header map, got %v", reparsed[":path"])
+	}
+	wantPseudoOrder := []string{":method", ":path"}
+	if got := reparsed[http.PHeaderOrderKey]; !equalStringSlices(got, wantPseudoOrder) {
+		t.Errorf("PHeaderOrderKey after round-trip = %v, want %v", got, wantPseudoOrder)
+	}
+	if _, ok := reparsed["cookie"]; ok {
+		t.Errorf("cookie should not be present in the reparsed header map, got %v", reparsed["cookie"])
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
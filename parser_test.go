@@ -0,0 +1,71 @@
+package http_template
+
+import (
+	"reflect"
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+func TestParseHeaderTemplate_Basic(t *testing.T) {
+	tmpl := "GET / HTTP/2\n" +
+		":method: GET\n" +
+		":authority: example.com\n" +
+		"Accept: text/html\n" +
+		"cookie: a=1\n" +
+		"cookie: b=2\n"
+
+	h, err := ParseHeaderTemplate(tmpl, nil)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplate returned error: %v", err)
+	}
+
+	if got, want := h.Get("Accept"), "text/html"; got != want {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+
+	if _, ok := h["cookie"]; ok {
+		t.Errorf("cookie should not be present in the header map, got %v", h["cookie"])
+	}
+
+	if got, want := h[http.HeaderOrderKey][1], "cookie"; got != want {
+		t.Errorf("HeaderOrderKey[1] = %q, want %q (deduped)", got, want)
+	}
+	if n := len(h[http.HeaderOrderKey]); n != 2 {
+		t.Errorf("HeaderOrderKey has %d entries, want 2 (cookie deduped): %v", n, h[http.HeaderOrderKey])
+	}
+
+	wantPseudo := []string{":method", ":authority"}
+	if got := h[http.PHeaderOrderKey]; !reflect.DeepEqual(got, wantPseudo) {
+		t.Errorf("PHeaderOrderKey = %v, want %v", got, wantPseudo)
+	}
+}
+
+func TestParseHeaderTemplate_Folding(t *testing.T) {
+	tmpl := "GET / HTTP/2\n" +
+		"Accept: text/html,\n" +
+		" application/json\n" +
+		"Accept-Language: en\n"
+
+	h, err := ParseHeaderTemplate(tmpl, nil)
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplate returned error: %v", err)
+	}
+
+	if got, want := h.Get("Accept"), "text/html, application/json"; got != want {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestParseHeaderTemplate_TemplateData(t *testing.T) {
+	tmpl := "GET / HTTP/2\nUser-Agent: {{.UA}}\n"
+
+	h, err := ParseHeaderTemplate(tmpl, struct{ UA string }{UA: "test-agent/1.0"})
+	if err != nil {
+		t.Fatalf("ParseHeaderTemplate returned error: %v", err)
+	}
+
+	if got, want := h.Get("User-Agent"), "test-agent/1.0"; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+}